@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/gfdmit/web-forum/post-service/config"
 	"github.com/gfdmit/web-forum/post-service/internal/app"
@@ -13,8 +16,10 @@ func main() {
 		log.Fatalf("[SETUP ERROR] error when reading config: %v", err)
 	}
 
-	err = app.Run(*conf)
-	if err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Run(ctx, *conf); err != nil {
 		log.Fatalf("[APPLICATION ERROR] error: %v", err)
 	}
 
@@ -27,6 +27,7 @@ type HTTPServer struct {
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"5s"`
 	ReadTimeout     time.Duration `env:"READ_TIMEOUT" env-default:"5s"`
 	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT" env-default:"5s"`
+	LogLevel        string        `env:"LOG_LEVEL" env-default:"info"`
 }
 
 func New(env string) (*Config, error) {
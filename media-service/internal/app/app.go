@@ -5,28 +5,40 @@ import (
 	"fmt"
 
 	"github.com/gfdmit/web-forum/post-service/config"
-	v1 "github.com/gfdmit/web-forum/post-service/internal/handlers/http/v1"
+	v1 "github.com/gfdmit/web-forum/media-service/internal/handlers/http/v1"
 	"github.com/gfdmit/web-forum/post-service/internal/httpserver"
-	"github.com/gfdmit/web-forum/post-service/internal/repository/minio"
-	"github.com/gfdmit/web-forum/post-service/internal/service"
+	"github.com/gfdmit/web-forum/post-service/internal/logging"
+	"github.com/gfdmit/web-forum/media-service/internal/repository/minio"
+	"github.com/gfdmit/web-forum/media-service/internal/service"
 )
 
-func Run(conf config.Config) error {
-	ctx := context.Background()
-	repo, err := minio.New(conf.MinIO)
+// Run wires up the service and blocks until ctx is cancelled (by the
+// signal.NotifyContext root context main creates), then shuts everything
+// down within conf.HTTPServer.ShutdownTimeout.
+func Run(ctx context.Context, conf config.Config) error {
+	logger := logging.New(conf.HTTPServer)
 
+	repo, err := minio.New(conf.MinIO)
 	if err != nil {
 		return fmt.Errorf("error when setting up repository: %v", err)
 	}
 
-	service := service.New(repo)
+	svc := service.New(repo)
 
-	handler, err := v1.New(service)
+	handler, err := v1.New(svc, logger)
 	if err != nil {
 		return fmt.Errorf("error when setting up handler: %v", err)
 	}
 
-	httpserver := httpserver.New(conf.HTTPServer, handler)
+	server := httpserver.New(conf.HTTPServer, handler)
+
+	runErr := server.Run(ctx, logger)
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), conf.HTTPServer.ShutdownTimeout)
+	defer cancel()
+	if err := svc.Shutdown(drainCtx); err != nil {
+		logger.Warn("uploads did not drain cleanly", "error", err)
+	}
 
-	return httpserver.Run(ctx)
+	return runErr
 }
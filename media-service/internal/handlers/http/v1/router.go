@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/gfdmit/web-forum/post-service/internal/service"
@@ -8,7 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func New(svc *service.Service) (*gin.Engine, error) {
+func New(svc *service.Service, logger *slog.Logger) (*gin.Engine, error) {
 	var (
 		router = gin.New()
 	)
@@ -24,7 +25,7 @@ func New(svc *service.Service) (*gin.Engine, error) {
 
 	apiGroup := router.Group("/api/v1")
 	{
-		apiGroup.Use(gin.Logger())
+		apiGroup.Use(RequestID(logger))
 
 		apiGroup.POST("/media", svc.PostImage)
 	}
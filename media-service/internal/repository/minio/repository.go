@@ -6,6 +6,7 @@ import (
 	"log"
 	"mime/multipart"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,12 +14,15 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 
 	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/gfdmit/web-forum/post-service/internal/logging"
 	"github.com/gfdmit/web-forum/post-service/internal/model"
 )
 
 type minioRepository struct {
 	cli    *minio.Client
 	bucket string
+
+	wg sync.WaitGroup
 }
 
 func New(conf config.MinIO) (*minioRepository, error) {
@@ -48,12 +52,16 @@ func New(conf config.MinIO) (*minioRepository, error) {
 	return repo, nil
 }
 
-func (mr minioRepository) PostImage(file multipart.File, header *multipart.FileHeader) (*model.MediaMeta, error) {
+func (mr *minioRepository) PostImage(ctx context.Context, file multipart.File, header *multipart.FileHeader) (*model.MediaMeta, error) {
+	mr.wg.Add(1)
+	defer mr.wg.Done()
+
+	logger := logging.FromContext(ctx)
 	ext := filepath.Ext(header.Filename)
 	objectName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
 
 	info, err := mr.cli.PutObject(
-		context.Background(),
+		ctx,
 		mr.bucket,
 		objectName,
 		file,
@@ -61,22 +69,41 @@ func (mr minioRepository) PostImage(file multipart.File, header *multipart.FileH
 		minio.PutObjectOptions{ContentType: header.Header.Get("Content-Type")},
 	)
 	if err != nil {
+		logger.Error("minio upload failed", "object", objectName, "error", err)
 		return nil, err
 	}
 
 	url, err := mr.cli.PresignedGetObject(
-		context.Background(),
+		ctx,
 		mr.bucket,
 		objectName,
 		24*time.Hour,
 		nil,
 	)
 	if err != nil {
+		logger.Error("minio presign failed", "object", objectName, "error", err)
 		return nil, err
 	}
 	mediaMeta := &model.MediaMeta{
 		Info: info,
 		Url:  url,
 	}
+	logger.Info("minio upload completed", "object", objectName, "size", header.Size)
 	return mediaMeta, nil
 }
+
+// Shutdown waits for in-flight uploads to finish, bounded by ctx.
+func (mr *minioRepository) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		mr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
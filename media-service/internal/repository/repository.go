@@ -1,11 +1,12 @@
 package repository
 
 import (
+	"context"
 	"mime/multipart"
 
 	"github.com/gfdmit/web-forum/post-service/internal/model"
 )
 
 type Repository interface {
-	PostImage(file multipart.File, header *multipart.FileHeader) (*model.MediaMeta, error)
+	PostImage(ctx context.Context, file multipart.File, header *multipart.FileHeader) (*model.MediaMeta, error)
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -17,6 +18,15 @@ func New(repo repository.Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// Shutdown drains in-flight uploads via the repository, if it supports
+// draining (the MinIO-backed one does).
+func (svc *Service) Shutdown(ctx context.Context) error {
+	if d, ok := svc.repo.(interface{ Shutdown(context.Context) error }); ok {
+		return d.Shutdown(ctx)
+	}
+	return nil
+}
+
 func (svc *Service) PostImage(c *gin.Context) {
 	file, header, err := c.Request.FormFile("image")
 	if err != nil {
@@ -28,7 +38,7 @@ func (svc *Service) PostImage(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	mediaMeta, err := svc.repo.PostImage(file, header)
+	mediaMeta, err := svc.repo.PostImage(c.Request.Context(), file, header)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Upload failed"})
 		return
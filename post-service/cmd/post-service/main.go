@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/gfdmit/web-forum/post-service/internal/app"
+)
+
+func main() {
+	conf, err := config.New(".env")
+	if err != nil {
+		log.Fatalf("[SETUP ERROR] error when reading config: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:], *conf); err != nil {
+			log.Fatalf("[MIGRATE ERROR] error: %v", err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Run(ctx, *conf); err != nil {
+		log.Fatalf("[APPLICATION ERROR] error: %v", err)
+	}
+
+	log.Println("[SHUTDOWN] service shut down gracefully")
+}
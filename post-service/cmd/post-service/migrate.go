@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/gfdmit/web-forum/post-service/internal/repository/postgres"
+	"github.com/gfdmit/web-forum/post-service/internal/repository/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// runMigrate implements the `post-service migrate list|up|down|goto
+// <version>` subcommand, opening a *migrate.Migrate for the backend
+// conf.Storage.Backend names rather than starting the service.
+// conf.Storage.DSN is not consulted here.
+func runMigrate(args []string, conf config.Config) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: post-service migrate list|up|down|goto <version>")
+	}
+
+	m, migrations, err := newMigrator(conf)
+	if err != nil {
+		return fmt.Errorf("error when setting up migrator: %v", err)
+	}
+
+	switch args[0] {
+	case "list":
+		return migrateList(m, migrations)
+	case "up":
+		return migrateRun(m.Up)
+	case "down":
+		return migrateRun(m.Down)
+	case "goto":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: post-service migrate goto <version>")
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %v", args[1], err)
+		}
+		return migrateRun(func() error { return m.Migrate(uint(version)) })
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q", args[0])
+	}
+}
+
+func newMigrator(conf config.Config) (*migrate.Migrate, string, error) {
+	switch conf.Storage.Backend {
+	case "sqlite":
+		m, err := sqlite.Migrator(conf.SQLite)
+		return m, conf.SQLite.Migrations, err
+	case "postgres", "":
+		m, err := postgres.Migrator(conf.Postgres)
+		return m, conf.Postgres.Migrations, err
+	default:
+		return nil, "", fmt.Errorf("unknown storage backend %q", conf.Storage.Backend)
+	}
+}
+
+func migrateRun(step func() error) error {
+	if err := step(); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			fmt.Println("nothing to migrate")
+			return nil
+		}
+		return err
+	}
+	fmt.Println("migrated successfully!")
+	return nil
+}
+
+var migrationVersionRe = regexp.MustCompile(`^(\d+)_`)
+
+// migrateList prints every version found in dir, marking the one the
+// database is currently at.
+func migrateList(m *migrate.Migrate, dir string) error {
+	current, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("m.Version: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("os.ReadDir: %v", err)
+	}
+
+	seen := map[uint64]bool{}
+	var versions []uint64
+	for _, entry := range entries {
+		match := migrationVersionRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || seen[version] {
+			continue
+		}
+		seen[version] = true
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	for _, version := range versions {
+		marker := "  "
+		if uint64(current) == version {
+			marker = "->"
+			if dirty {
+				marker = "->(dirty)"
+			}
+		}
+		fmt.Printf("%s %d\n", marker, version)
+	}
+	return nil
+}
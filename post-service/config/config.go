@@ -9,8 +9,21 @@ import (
 )
 
 type Config struct {
+	Storage
 	Postgres
+	SQLite
 	HTTPServer
+	GraphQL
+}
+
+// Storage selects and optionally addresses the repository backend. Backend
+// names which of the structured configs below (Postgres/SQLite) to build a
+// repository.Repository from; DSN, if set, takes precedence and is
+// dispatched through repository.Open's scheme registry instead (e.g.
+// "postgres://..." or "sqlite://...").
+type Storage struct {
+	Backend string `env:"STORAGE_BACKEND" env-default:"postgres"`
+	DSN     string `env:"STORAGE_DSN" env-default:""`
 }
 
 type Postgres struct {
@@ -20,7 +33,14 @@ type Postgres struct {
 	Port       string        `env:"POSTGRES_PORT" env-default:"8000"`
 	DB         string        `env:"POSTGRES_DB" env-default:"posts"`
 	Timeout    time.Duration `env:"POSTGRES_TIMEOUT" env-default:"5s"`
-	Migrations string        `env:"POSTGRES_MIGRATIONS" env-default:"./migrations"`
+	Migrations string        `env:"POSTGRES_MIGRATIONS" env-default:"./migrations/postgres"`
+}
+
+// SQLite configures the file-backed repository used when Storage.Backend is
+// "sqlite", e.g. for running post-service without a Postgres instance.
+type SQLite struct {
+	Path       string `env:"SQLITE_PATH" env-default:"./posts.db"`
+	Migrations string `env:"SQLITE_MIGRATIONS" env-default:"./migrations/sqlite"`
 }
 
 type HTTPServer struct {
@@ -29,6 +49,15 @@ type HTTPServer struct {
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"5s"`
 	ReadTimeout     time.Duration `env:"READ_TIMEOUT" env-default:"5s"`
 	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT" env-default:"5s"`
+	LogLevel        string        `env:"LOG_LEVEL" env-default:"info"`
+}
+
+type GraphQL struct {
+	MaxDepth                int  `env:"GRAPHQL_MAX_DEPTH" env-default:"10"`
+	MaxComplexity           int  `env:"GRAPHQL_MAX_COMPLEXITY" env-default:"1000"`
+	Introspection           bool `env:"GRAPHQL_INTROSPECTION" env-default:"true"`
+	PersistedQueries        bool `env:"GRAPHQL_PERSISTED_QUERIES" env-default:"false"`
+	PersistedQueryCacheSize int  `env:"GRAPHQL_PERSISTED_QUERY_CACHE_SIZE" env-default:"1000"`
 }
 
 func New(env string) (*Config, error) {
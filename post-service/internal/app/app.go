@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	v1 "github.com/gfdmit/web-forum/post-service/internal/handlers/http/v1"
+	"github.com/gfdmit/web-forum/post-service/internal/httpserver"
+	"github.com/gfdmit/web-forum/post-service/internal/logging"
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+	"github.com/gfdmit/web-forum/post-service/internal/repository/postgres"
+	"github.com/gfdmit/web-forum/post-service/internal/repository/sqlite"
+	"github.com/gfdmit/web-forum/post-service/internal/service"
+)
+
+// Run wires up the service and blocks until ctx is cancelled (by the
+// signal.NotifyContext root context main creates), then shuts everything
+// down within conf.HTTPServer.ShutdownTimeout.
+func Run(ctx context.Context, conf config.Config) error {
+	logger := logging.New(conf.HTTPServer)
+
+	repo, err := newRepository(conf)
+	if err != nil {
+		return fmt.Errorf("error when setting up repository: %v", err)
+	}
+
+	svc := service.New(repo)
+
+	handler, err := v1.New(svc, repo, conf.GraphQL, logger)
+	if err != nil {
+		return fmt.Errorf("error when setting up handler: %v", err)
+	}
+
+	server := httpserver.New(conf.HTTPServer, handler)
+
+	runErr := server.Run(ctx, logger)
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), conf.HTTPServer.ShutdownTimeout)
+	defer cancel()
+	if err := handler.Shutdown(drainCtx); err != nil {
+		logger.Warn("graphql subscriptions did not drain cleanly", "error", err)
+	}
+
+	return runErr
+}
+
+// newRepository opens the configured storage backend. If conf.Storage.DSN
+// is set it's dispatched through repository.Open's scheme registry
+// (postgres://, sqlite://); otherwise conf.Storage.Backend picks between
+// the structured Postgres/SQLite configs below. Either path satisfies
+// repository.Repository, so nothing upstream of this point needs to know
+// which one is running.
+func newRepository(conf config.Config) (repository.Repository, error) {
+	if conf.Storage.DSN != "" {
+		return repository.Open(conf.Storage.DSN)
+	}
+
+	switch conf.Storage.Backend {
+	case "sqlite":
+		return sqlite.New(conf.SQLite)
+	case "postgres", "":
+		return postgres.New(conf.Postgres)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", conf.Storage.Backend)
+	}
+}
@@ -0,0 +1,27 @@
+// Package dto holds the request-boundary helpers for translating between
+// the wire representation of an entity ID (a GraphQL ID scalar, always a
+// string) and the int64 IDs repository.Repository and service.Service take.
+// Parsing happens once here, at the handler boundary, rather than at every
+// call site down the stack.
+//
+// This is deliberately just ParseID, not a full request/response DTO layer:
+// post-service has no REST handler that marshals repository/model structs
+// directly (only /ping and the GraphQL endpoints exist, and GraphQL already
+// has its own generated input/output types), so a broader DTO layer would
+// have no caller.
+package dto
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseID validates and converts raw into the int64 ID type used throughout
+// the repository and service layers.
+func ParseID(raw string) (int64, error) {
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q: %w", raw, err)
+	}
+	return id, nil
+}
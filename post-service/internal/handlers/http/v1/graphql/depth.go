@@ -0,0 +1,75 @@
+package graphql
+
+import "github.com/vektah/gqlparser/v2/ast"
+
+// queryDepth returns the deepest nesting of selection sets across every
+// operation in doc. gqlparser has already resolved fragment spreads onto
+// their definitions by validation time, so no separate fragment map is
+// needed.
+func queryDepth(doc *ast.QueryDocument) int {
+	max := 0
+	for _, op := range doc.Operations {
+		if d := selectionSetDepth(op.SelectionSet, 0); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+func selectionSetDepth(set ast.SelectionSet, depth int) int {
+	max := depth
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			if d := selectionSetDepth(s.SelectionSet, depth+1); d > max {
+				max = d
+			}
+		case *ast.InlineFragment:
+			if d := selectionSetDepth(s.SelectionSet, depth); d > max {
+				max = d
+			}
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				if d := selectionSetDepth(s.Definition.SelectionSet, depth); d > max {
+					max = d
+				}
+			}
+		}
+	}
+	return max
+}
+
+// containsIntrospection reports whether doc selects __schema or __type
+// anywhere, used to reject introspection queries when conf.Introspection is
+// disabled.
+func containsIntrospection(doc *ast.QueryDocument) bool {
+	for _, op := range doc.Operations {
+		if selectionSetHasIntrospection(op.SelectionSet) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectionSetHasIntrospection(set ast.SelectionSet) bool {
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			if s.Name == "__schema" || s.Name == "__type" {
+				return true
+			}
+			if selectionSetHasIntrospection(s.SelectionSet) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if selectionSetHasIntrospection(s.SelectionSet) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if s.Definition != nil && selectionSetHasIntrospection(s.Definition.SelectionSet) {
+				return true
+			}
+		}
+	}
+	return false
+}
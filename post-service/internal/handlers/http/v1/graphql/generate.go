@@ -0,0 +1,6 @@
+package graphql
+
+// The executable schema in ./generated is produced from schema.graphql and
+// gqlgen.yml (both at the module root) by gqlgen; rerun this after editing
+// either the SDL or resolver.go's signatures.
+//go:generate go run github.com/99designs/gqlgen generate --config ../../../../../gqlgen.yml
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// persistedQueryCache is a fixed-size LRU mapping a persisted query's
+// sha256 hash to its full text. It implements gqlgen's graphql.Cache so it
+// can back extension.AutomaticPersistedQuery: clients send the hash on
+// every request and only send the full query once, the first time the
+// server reports a cache miss.
+type persistedQueryCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type persistedEntry struct {
+	key   string
+	value interface{}
+}
+
+func newPersistedQueryCache(capacity int) *persistedQueryCache {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &persistedQueryCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *persistedQueryCache) Get(ctx context.Context, key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*persistedEntry).value, true
+}
+
+func (c *persistedQueryCache) Add(ctx context.Context, key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*persistedEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&persistedEntry{key: key, value: value})
+	c.items[key] = el
+	if c.order.Len() > c.capacity {
+		back := c.order.Back()
+		if back != nil {
+			c.order.Remove(back)
+			delete(c.items, back.Value.(*persistedEntry).key)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/gfdmit/web-forum/post-service/internal/dto"
+	"github.com/gfdmit/web-forum/post-service/internal/handlers/http/v1/graphql/generated"
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+	"github.com/gfdmit/web-forum/post-service/internal/service"
+)
+
+// Resolver is the root struct gqlgen dependency-injects into every generated
+// <Type>Resolver. It holds the service for queries/mutations/events, the
+// repository for wiring
+// per-request DataLoaders, and a WaitGroup tracking in-flight subscriptions
+// so Server.Shutdown can drain them.
+type Resolver struct {
+	svc  *service.Service
+	repo repository.Repository
+	wg   sync.WaitGroup
+}
+
+func NewResolver(svc *service.Service, repo repository.Repository) *Resolver {
+	return &Resolver{svc: svc, repo: repo}
+}
+
+func (r *Resolver) Query() generated.QueryResolver               { return &queryResolver{r} }
+func (r *Resolver) Mutation() generated.MutationResolver         { return &mutationResolver{r} }
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+func (r *Resolver) Board() generated.BoardResolver               { return &boardResolver{r} }
+func (r *Resolver) Post() generated.PostResolver                 { return &postResolver{r} }
+
+type queryResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type boardResolver struct{ *Resolver }
+type postResolver struct{ *Resolver }
+
+const defaultNestedPageSize = 10
+
+func (q *queryResolver) Board(ctx context.Context, id string) (*model.Board, error) {
+	boardID, err := dto.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return q.svc.GetBoard(ctx, boardID)
+}
+
+func (q *queryResolver) Boards(ctx context.Context, includeDeleted *bool) ([]model.Board, error) {
+	return q.svc.GetBoards(ctx, boolArg(includeDeleted))
+}
+
+func (q *queryResolver) Post(ctx context.Context, id string) (*model.Post, error) {
+	postID, err := dto.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return q.svc.GetPost(ctx, postID)
+}
+
+func (q *queryResolver) Posts(ctx context.Context, boardID string, includeDeleted *bool, first *int, after *string, last *int, before *string) (*model.PostConnection, error) {
+	afterCursor, beforeCursor, err := decodePageArgs(after, before)
+	if err != nil {
+		return nil, err
+	}
+	boardIDInt, err := dto.ParseID(boardID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := q.svc.GetPosts(ctx, boardIDInt, boolArg(includeDeleted), first, afterCursor, last, beforeCursor)
+	if err != nil {
+		return nil, err
+	}
+	conn := model.NewPostConnection(page)
+	return &conn, nil
+}
+
+func (q *queryResolver) Comment(ctx context.Context, id string) (*model.Comment, error) {
+	commentID, err := dto.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return q.svc.GetComment(ctx, commentID)
+}
+
+func (q *queryResolver) Comments(ctx context.Context, postID string, includeDeleted *bool, first *int, after *string, last *int, before *string) (*model.CommentConnection, error) {
+	afterCursor, beforeCursor, err := decodePageArgs(after, before)
+	if err != nil {
+		return nil, err
+	}
+	postIDInt, err := dto.ParseID(postID)
+	if err != nil {
+		return nil, err
+	}
+	page, err := q.svc.GetComments(ctx, postIDInt, boolArg(includeDeleted), first, afterCursor, last, beforeCursor)
+	if err != nil {
+		return nil, err
+	}
+	conn := model.NewCommentConnection(page)
+	return &conn, nil
+}
+
+func boolArg(v *bool) bool {
+	return v != nil && *v
+}
+
+// decodePageArgs decodes the Relay Connection after/before cursor arguments.
+// first/last are passed through by gqlgen as *int already.
+func decodePageArgs(after, before *string) (*model.Cursor, *model.Cursor, error) {
+	var afterCursor, beforeCursor *model.Cursor
+	if after != nil && *after != "" {
+		c, err := model.DecodeCursor(*after)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid after cursor: %w", err)
+		}
+		afterCursor = &c
+	}
+	if before != nil && *before != "" {
+		c, err := model.DecodeCursor(*before)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid before cursor: %w", err)
+		}
+		beforeCursor = &c
+	}
+	return afterCursor, beforeCursor, nil
+}
+
+func (m *mutationResolver) CreateBoard(ctx context.Context, input generated.CreateBoardInput) (*model.Board, error) {
+	description := ""
+	if input.Description != nil {
+		description = *input.Description
+	}
+	return m.svc.CreateBoard(ctx, input.Name, description)
+}
+
+func (m *mutationResolver) DeleteBoard(ctx context.Context, id string) (bool, error) {
+	boardID, err := dto.ParseID(id)
+	if err != nil {
+		return false, err
+	}
+	return m.svc.DeleteBoard(ctx, boardID)
+}
+
+func (m *mutationResolver) RestoreBoard(ctx context.Context, id string) (bool, error) {
+	boardID, err := dto.ParseID(id)
+	if err != nil {
+		return false, err
+	}
+	return m.svc.RestoreBoard(ctx, boardID)
+}
+
+func (m *mutationResolver) CreatePost(ctx context.Context, input generated.CreatePostInput) (*model.Post, error) {
+	boardID, err := dto.ParseID(input.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	title := ""
+	if input.Title != nil {
+		title = *input.Title
+	}
+	hashIP := ""
+	if input.HashIp != nil {
+		hashIP = *input.HashIp
+	}
+	return m.svc.CreatePost(ctx, boardID, title, input.Text, hashIP)
+}
+
+func (m *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
+	postID, err := dto.ParseID(id)
+	if err != nil {
+		return false, err
+	}
+	return m.svc.DeletePost(ctx, postID)
+}
+
+func (m *mutationResolver) CreateComment(ctx context.Context, input generated.CreateCommentInput) (*model.Comment, error) {
+	postID, err := dto.ParseID(input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	hashIP := ""
+	if input.HashIp != nil {
+		hashIP = *input.HashIp
+	}
+	return m.svc.CreateComment(ctx, postID, input.Text, hashIP)
+}
+
+func (m *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
+	commentID, err := dto.ParseID(id)
+	if err != nil {
+		return false, err
+	}
+	return m.svc.DeleteComment(ctx, commentID)
+}
+
+// Board.posts and Post.comments resolve through the per-request DataLoaders
+// so a page of boards/posts each showing their first page of children costs
+// one extra query rather than one per parent.
+
+func (b *boardResolver) Posts(ctx context.Context, obj *model.Board, first *int, after *string) (*model.PostConnection, error) {
+	afterCursor, _, err := decodePageArgs(after, nil)
+	if err != nil {
+		return nil, err
+	}
+	n := defaultNestedPageSize
+	if first != nil {
+		n = *first
+	}
+	loaders := repository.LoadersFromContext(ctx)
+	if loaders == nil {
+		return nil, fmt.Errorf("dataloaders not present in request context")
+	}
+	page, err := loaders.PostsByBoardID(n, afterCursor).Load(ctx, strconv.FormatInt(obj.ID, 10))()
+	if err != nil {
+		return nil, err
+	}
+	conn := model.NewPostConnection(page)
+	return &conn, nil
+}
+
+func (p *postResolver) Comments(ctx context.Context, obj *model.Post, first *int, after *string) (*model.CommentConnection, error) {
+	afterCursor, _, err := decodePageArgs(after, nil)
+	if err != nil {
+		return nil, err
+	}
+	n := defaultNestedPageSize
+	if first != nil {
+		n = *first
+	}
+	loaders := repository.LoadersFromContext(ctx)
+	if loaders == nil {
+		return nil, fmt.Errorf("dataloaders not present in request context")
+	}
+	page, err := loaders.CommentsByPostID(n, afterCursor).Load(ctx, strconv.FormatInt(obj.ID, 10))()
+	if err != nil {
+		return nil, err
+	}
+	conn := model.NewCommentConnection(page)
+	return &conn, nil
+}
+
+// eventSubscription drains svc's event broker on its own goroutine,
+// forwarding only events matching want, until ctx is cancelled (client
+// unsubscribed or disconnected). The channel is closed when that happens,
+// which is also how gqlgen's websocket transport knows a subscription ended.
+// wg is the owning Resolver's WaitGroup, so Server.Shutdown can wait for
+// every in-flight subscription goroutine to exit before returning.
+func eventSubscription[T any](ctx context.Context, svc *service.Service, wg *sync.WaitGroup, want func(service.Event) (T, bool)) (<-chan T, error) {
+	events, unsubscribe := svc.Subscribe()
+	out := make(chan T)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(out)
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				if payload, match := want(e); match {
+					select {
+					case out <- payload:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *subscriptionResolver) PostCreated(ctx context.Context, boardID string) (<-chan *model.Post, error) {
+	return eventSubscription(ctx, s.svc, &s.wg, func(e service.Event) (*model.Post, bool) {
+		post, ok := e.Payload.(*model.Post)
+		return post, ok && e.Type == service.EventPostCreated && e.BoardID == boardID
+	})
+}
+
+func (s *subscriptionResolver) CommentCreated(ctx context.Context, postID string) (<-chan *model.Comment, error) {
+	return eventSubscription(ctx, s.svc, &s.wg, func(e service.Event) (*model.Comment, bool) {
+		comment, ok := e.Payload.(*model.Comment)
+		return comment, ok && e.Type == service.EventCommentCreated && e.PostID == postID
+	})
+}
+
+func (s *subscriptionResolver) PostDeleted(ctx context.Context) (<-chan string, error) {
+	return eventSubscription(ctx, s.svc, &s.wg, func(e service.Event) (string, bool) {
+		id, ok := e.Payload.(string)
+		return id, ok && e.Type == service.EventPostDeleted
+	})
+}
+
+func (s *subscriptionResolver) CommentDeleted(ctx context.Context) (<-chan string, error) {
+	return eventSubscription(ctx, s.svc, &s.wg, func(e service.Event) (string, bool) {
+		id, ok := e.Payload.(string)
+		return id, ok && e.Type == service.EventCommentDeleted
+	})
+}
@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/gfdmit/web-forum/post-service/internal/handlers/http/v1/graphql/generated"
+	"github.com/gfdmit/web-forum/post-service/internal/logging"
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+	"github.com/gfdmit/web-forum/post-service/internal/service"
+)
+
+const wsKeepAlive = 20 * time.Second
+
+// Server wraps the gqlgen-generated executable schema with the per-request
+// DataLoader injection and the query guardrails (introspection toggle, max
+// depth, max complexity, automatic persisted queries).
+type Server struct {
+	srv      *handler.Server
+	resolver *Resolver
+	conf     config.GraphQL
+}
+
+func New(svc *service.Service, repo repository.Repository, conf config.GraphQL) (*Server, error) {
+	resolver := NewResolver(svc, repo)
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.Websocket{KeepAlivePingInterval: wsKeepAlive})
+
+	if conf.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	if conf.MaxComplexity > 0 {
+		srv.Use(extension.FixedComplexityLimit(conf.MaxComplexity))
+	}
+	if conf.PersistedQueries {
+		srv.Use(extension.AutomaticPersistedQuery{Cache: newPersistedQueryCache(conf.PersistedQueryCacheSize)})
+	}
+
+	gh := &Server{srv: srv, resolver: resolver, conf: conf}
+	srv.AroundOperations(gh.enforceGuardrails)
+	srv.AroundOperations(gh.logOperation)
+
+	return gh, nil
+}
+
+// enforceGuardrails rejects queries that select introspection fields while
+// conf.Introspection is disabled, or that nest deeper than conf.MaxDepth.
+// Field-count complexity is handled separately by extension.FixedComplexityLimit
+// above; depth isn't, since gqlgen has no built-in depth limiter.
+func (gh *Server) enforceGuardrails(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+
+	if !gh.conf.Introspection && containsIntrospection(oc.Doc) {
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "introspection is disabled"))
+	}
+	if gh.conf.MaxDepth > 0 {
+		if depth := queryDepth(oc.Doc); depth > gh.conf.MaxDepth {
+			return graphql.OneShot(graphql.ErrorResponse(ctx, "query exceeds max depth of %d", gh.conf.MaxDepth))
+		}
+	}
+
+	return next(ctx)
+}
+
+// logOperation logs the operation name and total handling duration via the
+// request-scoped logger RequestID stashed in ctx (logging.FromContext falls
+// back to slog.Default for requests that bypass that middleware), so a
+// GraphQL call's log line correlates with the rest of that request's log
+// lines via request_id.
+func (gh *Server) logOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	start := time.Now()
+	oc := graphql.GetOperationContext(ctx)
+	logger := logging.FromContext(ctx)
+
+	respHandler := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		resp := respHandler(ctx)
+		logger.Info("graphql operation completed",
+			"operation", oc.OperationName,
+			"duration", time.Since(start),
+		)
+		return resp
+	}
+}
+
+func (gh *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(repository.WithLoaders(r.Context(), repository.NewLoaders(gh.resolver.repo)))
+	gh.srv.ServeHTTP(w, r)
+}
+
+// Shutdown waits for every in-flight GraphQL subscription to exit, bounded
+// by ctx; new HTTP connections are expected to already be rejected by the
+// caller (httpserver.Server.Run stops accepting them before this runs).
+func (gh *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		gh.resolver.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Playground returns the GraphQL Playground handler, mounted separately by
+// v1.New at /api/v1/playground so it can be omitted in production if ever
+// desired without touching the main endpoint.
+func Playground(endpoint string) http.Handler {
+	return playground.Handler("GraphQL Playground", endpoint)
+}
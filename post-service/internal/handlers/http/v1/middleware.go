@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gfdmit/web-forum/post-service/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestID generates (or forwards) an X-Request-ID header, binds it to a
+// child logger stored in the request context, and logs one line per
+// request with the correlated ID, route, status, and duration.
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader("X-Request-ID")
+		if id == "" {
+			id = uuid.New().String()
+		}
+		c.Writer.Header().Set("X-Request-ID", id)
+
+		reqLogger := logger.With("request_id", id)
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), reqLogger))
+
+		start := time.Now()
+		c.Next()
+
+		reqLogger.Info("request completed",
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"status", c.Writer.Status(),
+			"duration", time.Since(start),
+		)
+	}
+}
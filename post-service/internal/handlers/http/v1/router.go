@@ -1,16 +1,41 @@
 package v1
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/gfdmit/web-forum/post-service/config"
 	gql "github.com/gfdmit/web-forum/post-service/internal/handlers/http/v1/graphql"
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
 	"github.com/gfdmit/web-forum/post-service/internal/service"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func New(svc *service.Service) (*gin.Engine, error) {
+// drainable is satisfied by gql.New's handler: it serves HTTP and can drain
+// its in-flight WebSocket subscriptions on shutdown.
+type drainable interface {
+	http.Handler
+	Shutdown(ctx context.Context) error
+}
+
+// Handler is the service's top-level http.Handler. It embeds the gin engine
+// so it can be passed straight to httpserver.New, and additionally exposes
+// Shutdown so app.Run can drain in-flight GraphQL subscriptions once the
+// HTTP server itself has stopped accepting new requests.
+type Handler struct {
+	*gin.Engine
+	gql drainable
+}
+
+// Shutdown drains in-flight GraphQL subscriptions, bounded by ctx.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	return h.gql.Shutdown(ctx)
+}
+
+func New(svc *service.Service, repo repository.Repository, gqlConf config.GraphQL, logger *slog.Logger) (*Handler, error) {
 	var (
 		router = gin.New()
 	)
@@ -24,16 +49,32 @@ func New(svc *service.Service) (*gin.Engine, error) {
 		MaxAge:           300 * time.Second,
 	}))
 
-	gqlHandler, err := gql.New(svc)
+	gqlHandler, err := gql.New(svc, repo, gqlConf)
 	if err != nil {
 		return nil, err
 	}
 
+	// Also mounted at the bare /graphql path (outside the versioned REST
+	// group) so GraphQL clients that expect the conventional unversioned
+	// endpoint don't need to know about /api/v1. This is the same gqlgen
+	// schema as /api/v1/graphql, not a second implementation. It gets its
+	// own RequestID group rather than apiGroup's so these requests are
+	// still correlated/logged without applying the middleware twice to
+	// /api/v1/graphql.
+	bareGraphGroup := router.Group("")
+	{
+		bareGraphGroup.Use(RequestID(logger))
+
+		bareGraphGroup.Any("/graphql", gin.WrapH(gqlHandler))
+		bareGraphGroup.GET("/graphiql", gin.WrapH(gql.Playground("/graphql")))
+	}
+
 	apiGroup := router.Group("/api/v1")
 	{
-		apiGroup.Use(gin.Logger())
+		apiGroup.Use(RequestID(logger))
 
 		apiGroup.Any("/graphql", gin.WrapH(gqlHandler))
+		apiGroup.GET("/playground", gin.WrapH(gql.Playground("/api/v1/graphql")))
 
 		authGroup := apiGroup.Group("")
 		{
@@ -43,5 +84,5 @@ func New(svc *service.Service) (*gin.Engine, error) {
 		}
 	}
 
-	return router, nil
+	return &Handler{Engine: router, gql: gqlHandler}, nil
 }
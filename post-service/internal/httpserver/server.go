@@ -4,11 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/gfdmit/web-forum/post-service/config"
@@ -34,25 +31,31 @@ func New(conf config.HTTPServer, handler http.Handler) *Server {
 	return s
 }
 
-func (s *Server) Run(ctx context.Context) error {
-	log.Println("[HTTPSERVER] listening on:", s.server.Addr)
+// Run serves until ctx is cancelled by the caller (a signal.NotifyContext
+// root context threaded down from main), then shuts down, allowing
+// in-flight requests up to shutDownTimeout to finish.
+func (s *Server) Run(ctx context.Context, logger *slog.Logger) error {
+	logger.Info("http server listening", "addr", s.server.Addr)
 
+	errCh := make(chan error, 1)
 	go func() {
-		err := s.server.ListenAndServe()
-		if !errors.Is(err, http.ErrServerClosed) {
-			log.Println("[HTTPSERVER] http server error:", err)
+		if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
 		}
+		errCh <- nil
 	}()
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	<-sigChan
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
 
-	log.Println("[SHUTDOWN] http server shutdown")
+	logger.Info("http server shutting down")
 
-	ctx, cancel := context.WithTimeout(ctx, s.shutDownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutDownTimeout)
 	defer cancel()
 
-	return s.server.Shutdown(ctx)
+	return s.server.Shutdown(shutdownCtx)
 }
@@ -0,0 +1,41 @@
+// Package logging provides the process-wide structured logger and the
+// context plumbing that lets a request-scoped child logger (bound to an
+// X-Request-ID) travel down into repositories without every call needing an
+// extra parameter.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+)
+
+// New builds the process-wide structured logger from config.HTTPServer.
+func New(conf config.HTTPServer) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(conf.LogLevel)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}))
+}
+
+type ctxKey struct{}
+
+// WithLogger attaches logger to ctx, typically a child carrying the request
+// ID via logger.With("request_id", id).
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger stored by WithLogger, falling back to
+// slog.Default() for code paths that run outside a request (e.g. at
+// startup).
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
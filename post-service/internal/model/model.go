@@ -0,0 +1,130 @@
+// Package model holds the domain types shared by the repository, service,
+// and GraphQL layers. Keeping them outside internal/repository lets the
+// GraphQL schema (via gqlgen's autobind) and the persistence layer both
+// depend on the same structs without the schema layer importing storage
+// concerns.
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+type Board struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type Post struct {
+	ID        int64      `json:"id"`
+	BoardID   int64      `json:"board_id"`
+	Title     *string    `json:"title,omitempty"`
+	Text      string     `json:"text"`
+	HashIP    *string    `json:"hash_ip,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type Comment struct {
+	ID        int64      `json:"id"`
+	PostID    int64      `json:"post_id"`
+	Text      string     `json:"text"`
+	HashIP    *string    `json:"hash_ip,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Cursor is the (createdAt, id) tuple a Relay-style connection cursor is
+// built from. Keying on the tuple rather than id alone keeps keyset
+// pagination stable when rows share a created_at value.
+type Cursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int64     `json:"id"`
+}
+
+func EncodeCursor(c Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Cursor{}, err
+	}
+	return c, nil
+}
+
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     string
+	EndCursor       string
+}
+
+type PostPage struct {
+	Posts      []Post
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type CommentPage struct {
+	Comments   []Comment
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+// PostEdge/PostConnection and CommentEdge/CommentConnection are the Relay
+// Connection shapes exposed over GraphQL, autobound to the matching
+// PostConnection/CommentConnection types in schema.graphql.
+type PostEdge struct {
+	Node   Post
+	Cursor string
+}
+
+type PostConnection struct {
+	Edges      []PostEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type CommentEdge struct {
+	Node   Comment
+	Cursor string
+}
+
+type CommentConnection struct {
+	Edges      []CommentEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+func NewPostConnection(page PostPage) PostConnection {
+	edges := make([]PostEdge, len(page.Posts))
+	for i, post := range page.Posts {
+		edges[i] = PostEdge{
+			Node:   post,
+			Cursor: EncodeCursor(Cursor{CreatedAt: post.CreatedAt, ID: post.ID}),
+		}
+	}
+	return PostConnection{Edges: edges, PageInfo: page.PageInfo, TotalCount: page.TotalCount}
+}
+
+func NewCommentConnection(page CommentPage) CommentConnection {
+	edges := make([]CommentEdge, len(page.Comments))
+	for i, comment := range page.Comments {
+		edges[i] = CommentEdge{
+			Node:   comment,
+			Cursor: EncodeCursor(Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID}),
+		}
+	}
+	return CommentConnection{Edges: edges, PageInfo: page.PageInfo, TotalCount: page.TotalCount}
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaderWait is how long a loader waits for more keys to arrive before
+// dispatching a batch; short enough that it's invisible to a single
+// request but long enough to coalesce sibling field resolutions.
+const loaderWait = 2 * time.Millisecond
+
+// Loaders groups per-request DataLoader instances. A fresh *Loaders must be
+// created for each incoming GraphQL request and stored in its context via
+// WithLoaders, so caching never leaks across requests.
+type Loaders struct {
+	repo      Repository
+	BoardByID *dataloader.Loader[string, *model.Board]
+
+	mu           sync.Mutex
+	postPages    map[pageLoaderKey]*dataloader.Loader[string, model.PostPage]
+	commentPages map[pageLoaderKey]*dataloader.Loader[string, model.CommentPage]
+}
+
+// pageLoaderKey identifies a nested-connection loader by the page arguments
+// its siblings in the same selection set share (e.g. every model.Board.posts field
+// in a boards query resolves with the same first/after, so they share one
+// batched loader and thus one query for the whole page).
+type pageLoaderKey struct {
+	first int
+	after string
+}
+
+func NewLoaders(repo Repository) *Loaders {
+	return &Loaders{
+		repo: repo,
+		BoardByID: dataloader.NewBatchedLoader(
+			boardByIDBatch(repo),
+			dataloader.WithWait[string, *model.Board](loaderWait),
+		),
+		postPages:    make(map[pageLoaderKey]*dataloader.Loader[string, model.PostPage]),
+		commentPages: make(map[pageLoaderKey]*dataloader.Loader[string, model.CommentPage]),
+	}
+}
+
+// PostsByBoardID returns the loader for model.Board.posts nested connections
+// sharing this (first, after) page. A separate loader is kept per distinct
+// page argument combination so that each still batches across sibling
+// boards in the same query.
+func (l *Loaders) PostsByBoardID(first int, after *model.Cursor) *dataloader.Loader[string, model.PostPage] {
+	key := pageLoaderKey{first: first}
+	if after != nil {
+		key.after = model.EncodeCursor(*after)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if loader, ok := l.postPages[key]; ok {
+		return loader
+	}
+	loader := dataloader.NewBatchedLoader(
+		postsByBoardIDBatch(l.repo, first, after),
+		dataloader.WithWait[string, model.PostPage](loaderWait),
+	)
+	l.postPages[key] = loader
+	return loader
+}
+
+// CommentsByPostID is the comment-side counterpart of PostsByBoardID.
+func (l *Loaders) CommentsByPostID(first int, after *model.Cursor) *dataloader.Loader[string, model.CommentPage] {
+	key := pageLoaderKey{first: first}
+	if after != nil {
+		key.after = model.EncodeCursor(*after)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if loader, ok := l.commentPages[key]; ok {
+		return loader
+	}
+	loader := dataloader.NewBatchedLoader(
+		commentsByPostIDBatch(l.repo, first, after),
+		dataloader.WithWait[string, model.CommentPage](loaderWait),
+	)
+	l.commentPages[key] = loader
+	return loader
+}
+
+// parseKeys converts the string keys a *dataloader.Loader hands batch
+// functions (always produced internally via strconv.FormatInt(obj.ID, 10),
+// never from raw user input) into the int64 IDs Repository expects.
+func parseKeys(keys []string) ([]int64, error) {
+	ids := make([]int64, len(keys))
+	for i, key := range keys {
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loader key %q: %w", key, err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func boardByIDBatch(repo Repository) dataloader.BatchFunc[string, *model.Board] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[*model.Board] {
+		results := make([]*dataloader.Result[*model.Board], len(keys))
+
+		ids, err := parseKeys(keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*model.Board]{Error: err}
+			}
+			return results
+		}
+
+		boards, err := repo.GetBoardsByIDs(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*model.Board]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[int64]*model.Board, len(boards))
+		for i := range boards {
+			byID[boards[i].ID] = &boards[i]
+		}
+		for i, id := range ids {
+			if b, ok := byID[id]; ok {
+				results[i] = &dataloader.Result[*model.Board]{Data: b}
+			} else {
+				results[i] = &dataloader.Result[*model.Board]{Error: sql.ErrNoRows}
+			}
+		}
+		return results
+	}
+}
+
+func postsByBoardIDBatch(repo Repository, first int, after *model.Cursor) dataloader.BatchFunc[string, model.PostPage] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[model.PostPage] {
+		results := make([]*dataloader.Result[model.PostPage], len(keys))
+
+		ids, err := parseKeys(keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[model.PostPage]{Error: err}
+			}
+			return results
+		}
+
+		pages, err := repo.GetPostsByBoardIDsPage(ctx, ids, first, after)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[model.PostPage]{Error: err}
+			}
+			return results
+		}
+		for i, id := range ids {
+			results[i] = &dataloader.Result[model.PostPage]{Data: pages[id]}
+		}
+		return results
+	}
+}
+
+func commentsByPostIDBatch(repo Repository, first int, after *model.Cursor) dataloader.BatchFunc[string, model.CommentPage] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[model.CommentPage] {
+		results := make([]*dataloader.Result[model.CommentPage], len(keys))
+
+		ids, err := parseKeys(keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[model.CommentPage]{Error: err}
+			}
+			return results
+		}
+
+		pages, err := repo.GetCommentsByPostIDsPage(ctx, ids, first, after)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[model.CommentPage]{Error: err}
+			}
+			return results
+		}
+		for i, id := range ids {
+			results[i] = &dataloader.Result[model.CommentPage]{Data: pages[id]}
+		}
+		return results
+	}
+}
+
+type loadersCtxKey struct{}
+
+// WithLoaders stores loaders in ctx for downstream resolvers to pick up via
+// LoadersFromContext.
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersCtxKey{}, loaders)
+}
+
+// LoadersFromContext returns the *Loaders stored by WithLoaders, or nil if
+// none was set.
+func LoadersFromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersCtxKey{}).(*Loaders)
+	return loaders
+}
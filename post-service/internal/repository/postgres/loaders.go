@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+	"github.com/lib/pq"
+)
+
+func (pr postgresRepository) GetBoardsByIDs(ctx context.Context, ids []int64) ([]model.Board, error) {
+	rows, err := pr.db.QueryContext(ctx, "SELECT * FROM posts.boards WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var boards []model.Board
+	for rows.Next() {
+		board := model.Board{}
+		if err := rows.Scan(&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt); err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+	return boards, rows.Err()
+}
+
+// scanPostRowWithTotal is scanPostRow plus the per-board total column the
+// batched queries below add via count(*) OVER (PARTITION BY board_id), so
+// nested PostConnection.totalCount reflects the real child count rather
+// than the page length.
+func scanPostRowWithTotal(rows *sql.Rows) (model.Post, int, error) {
+	var post model.Post
+	var total int
+	err := rows.Scan(&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt, &total)
+	return post, total, err
+}
+
+func scanCommentRowWithTotal(rows *sql.Rows) (model.Comment, int, error) {
+	var comment model.Comment
+	var total int
+	err := rows.Scan(&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt, &total)
+	return comment, total, err
+}
+
+// GetPostsByBoardIDsPage returns, for each board in boardIDs, its first page
+// of non-deleted posts (newest first) in a single query, keyed by board ID.
+// Pagination is done per-parent via row_number() so every board's window
+// starts back at 1, matching the semantics of a standalone GetPosts(first,
+// after) call for that board. The total column comes from a count(*) window
+// over the un-paginated (but cursor-independent) filtered set, computed
+// before the cursor condition is applied, so it reports the board's real
+// child count rather than the number of rows returned for this page.
+func (pr postgresRepository) GetPostsByBoardIDsPage(ctx context.Context, boardIDs []int64, first int, after *model.Cursor) (map[int64]model.PostPage, error) {
+	query := `
+		SELECT id, board_id, title, text, hash_ip, created_at, deleted_at, total FROM (
+			SELECT *, row_number() OVER (PARTITION BY board_id ORDER BY created_at DESC, id DESC) AS rn
+			FROM (
+				SELECT *, count(*) OVER (PARTITION BY board_id) AS total
+				FROM posts.posts
+				WHERE deleted_at IS NULL AND board_id = ANY($1)
+			) totaled`
+	args := []interface{}{pq.Array(boardIDs)}
+	if after != nil {
+		query += " WHERE (created_at, id) < ($2, $3)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+		) ranked WHERE rn <= $` + strconv.Itoa(len(args)+1) + `
+		ORDER BY board_id, created_at DESC, id DESC`
+	args = append(args, first+1)
+
+	rows, err := pr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byBoard := make(map[int64][]model.Post)
+	totals := make(map[int64]int)
+	for rows.Next() {
+		post, total, err := scanPostRowWithTotal(rows)
+		if err != nil {
+			return nil, err
+		}
+		byBoard[post.BoardID] = append(byBoard[post.BoardID], post)
+		totals[post.BoardID] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pages := make(map[int64]model.PostPage, len(byBoard))
+	for k, posts := range byBoard {
+		hasNext := len(posts) > first
+		if hasNext {
+			posts = posts[:first]
+		}
+		pages[k] = newPostPage(posts, hasNext, after != nil, totals[k])
+	}
+	return pages, nil
+}
+
+// GetCommentsByPostIDsPage is the comment-side counterpart of
+// GetPostsByBoardIDsPage, windowed per post_id instead of board_id.
+func (pr postgresRepository) GetCommentsByPostIDsPage(ctx context.Context, postIDs []int64, first int, after *model.Cursor) (map[int64]model.CommentPage, error) {
+	query := `
+		SELECT id, post_id, text, hash_ip, created_at, deleted_at, total FROM (
+			SELECT *, row_number() OVER (PARTITION BY post_id ORDER BY created_at DESC, id DESC) AS rn
+			FROM (
+				SELECT *, count(*) OVER (PARTITION BY post_id) AS total
+				FROM posts.comments
+				WHERE deleted_at IS NULL AND post_id = ANY($1)
+			) totaled`
+	args := []interface{}{pq.Array(postIDs)}
+	if after != nil {
+		query += " WHERE (created_at, id) < ($2, $3)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+		) ranked WHERE rn <= $` + strconv.Itoa(len(args)+1) + `
+		ORDER BY post_id, created_at DESC, id DESC`
+	args = append(args, first+1)
+
+	rows, err := pr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byPost := make(map[int64][]model.Comment)
+	totals := make(map[int64]int)
+	for rows.Next() {
+		comment, total, err := scanCommentRowWithTotal(rows)
+		if err != nil {
+			return nil, err
+		}
+		byPost[comment.PostID] = append(byPost[comment.PostID], comment)
+		totals[comment.PostID] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pages := make(map[int64]model.CommentPage, len(byPost))
+	for k, comments := range byPost {
+		hasNext := len(comments) > first
+		if hasNext {
+			comments = comments[:first]
+		}
+		pages[k] = newCommentPage(comments, hasNext, after != nil, totals[k])
+	}
+	return pages, nil
+}
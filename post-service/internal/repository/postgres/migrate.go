@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// Migrator opens conf's database and wires it to the migrations directory
+// conf.Migrations points at, for the standalone `post-service migrate` CLI
+// subcommand. It's kept separate from New so starting the service and
+// running its migrations are independent operations.
+func Migrator(conf config.Postgres) (*migrate.Migrate, error) {
+	url := fmt.Sprintf(
+		"postgresql://%v:%v@%v:%v/%v?sslmode=disable", conf.User, conf.Pass, conf.Host, conf.Port, conf.DB)
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %v", err)
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("postgres.WithInstance: %v", err)
+	}
+	migrations := fmt.Sprintf("file://%v", conf.Migrations)
+	m, err := migrate.NewWithDatabaseInstance(migrations, conf.DB, driver)
+	if err != nil {
+		return nil, fmt.Errorf("migrate.NewWithDatabaseInstance: %v", err)
+	}
+	return m, nil
+}
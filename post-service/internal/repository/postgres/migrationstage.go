@@ -0,0 +1,27 @@
+package postgres
+
+import "context"
+
+// StoreMigrationStage upserts payload under id, so re-staging the same
+// snapshot (e.g. a retried export job) overwrites rather than conflicts.
+func (pr postgresRepository) StoreMigrationStage(p context.Context, id string, payload []byte) error {
+	_, err := pr.db.ExecContext(p,
+		`INSERT INTO posts.migration_stages (id, payload) VALUES ($1, $2)
+		 ON CONFLICT (id) DO UPDATE SET payload = EXCLUDED.payload, created_at = NOW()`,
+		id, payload)
+	return err
+}
+
+func (pr postgresRepository) GetMigrationStage(p context.Context, id string) ([]byte, error) {
+	var payload []byte
+	err := pr.db.QueryRowContext(p, "SELECT payload FROM posts.migration_stages WHERE id = $1", id).Scan(&payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (pr postgresRepository) DeleteMigrationStage(p context.Context, id string) error {
+	_, err := pr.db.ExecContext(p, "DELETE FROM posts.migration_stages WHERE id = $1", id)
+	return err
+}
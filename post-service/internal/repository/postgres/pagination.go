@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+)
+
+// scanPostRow and scanCommentRow adapt sql.Rows to the generic paginate
+// helper below, which only knows how to Scan into a model.Cursor-ordered
+// row and hand the typed value back to the caller.
+func scanPostRow(rows *sql.Rows) (model.Post, error) {
+	var post model.Post
+	err := rows.Scan(&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt)
+	return post, err
+}
+
+func scanCommentRow(rows *sql.Rows) (model.Comment, error) {
+	var comment model.Comment
+	err := rows.Scan(&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt)
+	return comment, err
+}
+
+// paginate runs a keyset (createdAt, id) pagination query against table,
+// filtered by parentColumn = parentID, and scans rows with scan. It supports
+// forward pagination (first/after) and backward pagination (last/before);
+// exactly one direction is expected to be set by the caller. It fetches one
+// extra row beyond the requested page size to determine hasNextPage/
+// hasPreviousPage without a second round trip, and trims it from the result.
+func paginate[T any](
+	ctx context.Context,
+	db *sql.DB,
+	table string,
+	parentColumn string,
+	parentID int64,
+	includeDeleted bool,
+	first *int,
+	after *model.Cursor,
+	last *int,
+	before *model.Cursor,
+	scan func(*sql.Rows) (T, error),
+) ([]T, bool, bool, error) {
+	backward := last != nil
+
+	where := fmt.Sprintf("%s = $1", parentColumn)
+	args := []interface{}{parentID}
+	if !includeDeleted {
+		where += " AND deleted_at IS NULL"
+	}
+
+	order := "created_at DESC, id DESC"
+	limit := 10
+	if first != nil {
+		limit = *first
+	}
+	if backward {
+		order = "created_at ASC, id ASC"
+		limit = *last
+	}
+
+	cursor := after
+	if backward {
+		cursor = before
+	}
+	if cursor != nil {
+		cmp := "<"
+		if backward {
+			cmp = ">"
+		}
+		where += fmt.Sprintf(" AND (created_at, id) %s ($%d, $%d)", cmp, len(args)+1, len(args)+2)
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM %s WHERE %s ORDER BY %s LIMIT $%d", table, where, order, len(args)+1)
+	args = append(args, limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, false, err
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, false, false, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, false, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	hasNext, hasPrev := hasMore, cursor != nil
+	if backward {
+		hasNext, hasPrev = cursor != nil, hasMore
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	return items, hasNext, hasPrev, nil
+}
+
+func newPostPage(posts []model.Post, hasNext, hasPrev bool, total int) model.PostPage {
+	return model.PostPage{Posts: posts, PageInfo: postPageInfo(posts, hasNext, hasPrev), TotalCount: total}
+}
+
+func newCommentPage(comments []model.Comment, hasNext, hasPrev bool, total int) model.CommentPage {
+	return model.CommentPage{Comments: comments, PageInfo: commentPageInfo(comments, hasNext, hasPrev), TotalCount: total}
+}
+
+func postPageInfo(posts []model.Post, hasNext, hasPrev bool) model.PageInfo {
+	info := model.PageInfo{HasNextPage: hasNext, HasPreviousPage: hasPrev}
+	if len(posts) == 0 {
+		return info
+	}
+	info.StartCursor = model.EncodeCursor(model.Cursor{CreatedAt: posts[0].CreatedAt, ID: posts[0].ID})
+	info.EndCursor = model.EncodeCursor(model.Cursor{CreatedAt: posts[len(posts)-1].CreatedAt, ID: posts[len(posts)-1].ID})
+	return info
+}
+
+func commentPageInfo(comments []model.Comment, hasNext, hasPrev bool) model.PageInfo {
+	info := model.PageInfo{HasNextPage: hasNext, HasPreviousPage: hasPrev}
+	if len(comments) == 0 {
+		return info
+	}
+	info.StartCursor = model.EncodeCursor(model.Cursor{CreatedAt: comments[0].CreatedAt, ID: comments[0].ID})
+	info.EndCursor = model.EncodeCursor(model.Cursor{CreatedAt: comments[len(comments)-1].CreatedAt, ID: comments[len(comments)-1].ID})
+	return info
+}
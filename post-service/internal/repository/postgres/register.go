@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+)
+
+func init() {
+	repository.Register("postgres", openDSN)
+}
+
+// openDSN builds a postgresRepository straight from a "postgres://" DSN,
+// for repository.Open. It skips conf.Postgres entirely: lib/pq accepts the
+// DSN as-is, so there's nothing to assemble beyond sql.Open.
+func openDSN(dsn string) (repository.Repository, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %v", err)
+	}
+	if err := db.PingContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("db.PingContext: %v", err)
+	}
+	return &postgresRepository{db: db}, nil
+}
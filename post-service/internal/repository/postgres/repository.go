@@ -5,17 +5,11 @@ import (
 	"crypto/sha1"
 	"database/sql"
 	"encoding/base64"
-	"errors"
 	"fmt"
-	"log"
-	"strconv"
 
 	"github.com/gfdmit/web-forum/post-service/config"
-	"github.com/gfdmit/web-forum/post-service/internal/repository"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/gfdmit/web-forum/post-service/internal/model"
 
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
 )
 
@@ -31,28 +25,8 @@ func New(conf config.Postgres) (*postgresRepository, error) {
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open: %v", err)
 	}
-	err = db.Ping()
-	if err != nil {
-		return nil, fmt.Errorf("db.Ping: %v", err)
-	}
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("postgers.WithInstance: %v", err)
-	}
-	migrations := fmt.Sprintf("file://%v", conf.Migrations)
-	m, err := migrate.NewWithDatabaseInstance(migrations, conf.DB, driver)
-	if err != nil {
-		return nil, fmt.Errorf("migrate.NewWithDatabaseInstance: %v", err)
-	}
-	log.Println("applying migrations...")
-	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("nothing to migrate")
-		} else {
-			return nil, fmt.Errorf("error when migrating: %v", err)
-		}
-	} else {
-		log.Println("migrated successfully!")
+	if err := db.PingContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("db.PingContext: %v", err)
 	}
 
 	return &postgresRepository{
@@ -60,206 +34,200 @@ func New(conf config.Postgres) (*postgresRepository, error) {
 	}, nil
 }
 
-func (pr postgresRepository) GetBoard(p context.Context, id string) (interface{}, error) {
-	board := &repository.Board{}
-	err := pr.db.QueryRow("SELECT * FROM posts.boards WHERE id = $1", id).Scan(
+func (pr postgresRepository) GetBoard(p context.Context, id int64) (*model.Board, error) {
+	board := &model.Board{}
+	err := pr.db.QueryRowContext(p, "SELECT * FROM posts.boards WHERE id = $1", id).Scan(
 		&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("GetBoard: %w", err)
 	}
 	return board, nil
 }
 
-func (pr postgresRepository) GetBoards(p context.Context, includeDeleted bool) (interface{}, error) {
-	boards := []repository.Board{}
+func (pr postgresRepository) GetBoards(p context.Context, includeDeleted bool) ([]model.Board, error) {
+	boards := []model.Board{}
 	var (
 		rows *sql.Rows
 		err  error
 	)
 	if includeDeleted {
-		rows, err = pr.db.Query("SELECT * FROM posts.boards")
+		rows, err = pr.db.QueryContext(p, "SELECT * FROM posts.boards")
 	} else {
-		rows, err = pr.db.Query("SELECT * FROM posts.boards WHERE deleted_at IS NULL")
+		rows, err = pr.db.QueryContext(p, "SELECT * FROM posts.boards WHERE deleted_at IS NULL")
 	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("GetBoards: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		board := repository.Board{}
+		board := model.Board{}
 		err = rows.Scan(&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("GetBoards: %w", err)
 		}
 		boards = append(boards, board)
 	}
 	return boards, nil
 }
 
-func (pr postgresRepository) GetPost(p context.Context, id string) (interface{}, error) {
-	post := &repository.Post{}
-	err := pr.db.QueryRow("SELECT * FROM posts.posts WHERE id = $1", id).Scan(
+func (pr postgresRepository) GetPost(p context.Context, id int64) (*model.Post, error) {
+	post := &model.Post{}
+	err := pr.db.QueryRowContext(p, "SELECT * FROM posts.posts WHERE id = $1", id).Scan(
 		&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("GetPost: %w", err)
 	}
 	return post, nil
 }
 
-func (pr postgresRepository) GetPosts(p context.Context, boardID string, includeDeleted bool, limit int, offset int) (interface{}, error) {
-	posts := []repository.Post{}
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if includeDeleted {
-		rows, err = pr.db.Query("SELECT * FROM posts.posts WHERE board_id = $1 ORDER BY created_at LIMIT $2 OFFSET $3", boardID, limit, offset)
-	} else {
-		rows, err = pr.db.Query("SELECT * FROM posts.posts WHERE deleted_at IS NULL AND board_id = $1 ORDER BY created_at LIMIT $2 OFFSET $3", boardID, limit, offset)
+func (pr postgresRepository) GetPosts(ctx context.Context, boardID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.PostPage, error) {
+	posts, hasNext, hasPrev, err := paginate(ctx, pr.db, "posts.posts", "board_id", boardID, includeDeleted, first, after, last, before, scanPostRow)
+	if err != nil {
+		return model.PostPage{}, fmt.Errorf("GetPosts: %w", err)
 	}
+
+	total, err := pr.countPosts(ctx, boardID, includeDeleted)
 	if err != nil {
-		return nil, err
+		return model.PostPage{}, fmt.Errorf("GetPosts: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		post := repository.Post{}
-		err = rows.Scan(
-			&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt)
-		if err != nil {
-			return nil, err
-		}
-		posts = append(posts, post)
+	return newPostPage(posts, hasNext, hasPrev, total), nil
+}
+
+func (pr postgresRepository) countPosts(ctx context.Context, boardID int64, includeDeleted bool) (int, error) {
+	query := "SELECT COUNT(*) FROM posts.posts WHERE board_id = $1"
+	if !includeDeleted {
+		query += " AND deleted_at IS NULL"
 	}
-	return posts, nil
+
+	var count int
+	err := pr.db.QueryRowContext(ctx, query, boardID).Scan(&count)
+	return count, err
 }
 
-func (pr postgresRepository) GetComment(p context.Context, id string) (interface{}, error) {
-	comment := &repository.Comment{}
-	err := pr.db.QueryRow("SELECT * FROM posts.comments WHERE id = $1", id).Scan(
+func (pr postgresRepository) GetComment(p context.Context, id int64) (*model.Comment, error) {
+	comment := &model.Comment{}
+	err := pr.db.QueryRowContext(p, "SELECT * FROM posts.comments WHERE id = $1", id).Scan(
 		&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("GetComment: %w", err)
 	}
 	return comment, nil
 }
 
-func (pr postgresRepository) GetComments(p context.Context, postID string, includeDeleted bool, limit int, offset int) (interface{}, error) {
-	comments := []repository.Comment{}
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if includeDeleted {
-		rows, err = pr.db.Query("SELECT * FROM posts.comments WHERE post_id = $1 ORDER BY created_at LIMIT $2 OFFSET $3", postID, limit, offset)
-	} else {
-		rows, err = pr.db.Query("SELECT * FROM posts.comments WHERE deleted_at IS NULL AND post_id = $1 ORDER BY created_at LIMIT $2 OFFSET $3", postID, limit, offset)
+func (pr postgresRepository) GetComments(ctx context.Context, postID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.CommentPage, error) {
+	comments, hasNext, hasPrev, err := paginate(ctx, pr.db, "posts.comments", "post_id", postID, includeDeleted, first, after, last, before, scanCommentRow)
+	if err != nil {
+		return model.CommentPage{}, fmt.Errorf("GetComments: %w", err)
 	}
+
+	total, err := pr.countComments(ctx, postID, includeDeleted)
 	if err != nil {
-		return nil, err
+		return model.CommentPage{}, fmt.Errorf("GetComments: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		comment := repository.Comment{}
-		err = rows.Scan(
-			&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt)
-		if err != nil {
-			return nil, err
-		}
-		comments = append(comments, comment)
+	return newCommentPage(comments, hasNext, hasPrev, total), nil
+}
+
+func (pr postgresRepository) countComments(ctx context.Context, postID int64, includeDeleted bool) (int, error) {
+	query := "SELECT COUNT(*) FROM posts.comments WHERE post_id = $1"
+	if !includeDeleted {
+		query += " AND deleted_at IS NULL"
 	}
-	return comments, nil
+
+	var count int
+	err := pr.db.QueryRowContext(ctx, query, postID).Scan(&count)
+	return count, err
 }
 
-func (pr postgresRepository) CreateBoard(p context.Context, name string, description string) (interface{}, error) {
+func (pr postgresRepository) CreateBoard(p context.Context, name string, description string) (*model.Board, error) {
 	var (
-		boardId int
+		boardId int64
 	)
-	err := pr.db.QueryRow("INSERT INTO posts.boards (name, description) VALUES($1, $2) RETURNING id", name, description).Scan(&boardId)
+	err := pr.db.QueryRowContext(p, "INSERT INTO posts.boards (name, description) VALUES($1, $2) RETURNING id", name, description).Scan(&boardId)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("CreateBoard: %w", err)
 	}
-	return pr.GetBoard(p, strconv.Itoa(boardId))
+	return pr.GetBoard(p, boardId)
 }
 
-func (pr postgresRepository) DeleteBoard(p context.Context, id string) (interface{}, error) {
-	stmt, err := pr.db.Prepare("UPDATE posts.boards SET deleted_at = NOW() WHERE id = $1")
+func (pr postgresRepository) DeleteBoard(p context.Context, id int64) (bool, error) {
+	stmt, err := pr.db.PrepareContext(p, "UPDATE posts.boards SET deleted_at = NOW() WHERE id = $1")
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeleteBoard: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(p, id)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeleteBoard: %w", err)
 	}
 	return true, nil
 }
 
-func (pr postgresRepository) RestoreBoard(p context.Context, id string) (interface{}, error) {
-	stmt, err := pr.db.Prepare("UPDATE posts.boards SET deleted_at = NULL WHERE id = $1")
+func (pr postgresRepository) RestoreBoard(p context.Context, id int64) (bool, error) {
+	stmt, err := pr.db.PrepareContext(p, "UPDATE posts.boards SET deleted_at = NULL WHERE id = $1")
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("RestoreBoard: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(p, id)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("RestoreBoard: %w", err)
 	}
 	return true, nil
 }
 
-func (pr postgresRepository) CreatePost(p context.Context, boardId string, title string, text string, hashIp string) (interface{}, error) {
+func (pr postgresRepository) CreatePost(p context.Context, boardId int64, title string, text string, hashIp string) (*model.Post, error) {
 	var (
-		postId int
+		postId int64
 	)
 	hashIp = hashingIP(hashIp)
-	err := pr.db.QueryRow("INSERT INTO posts.posts (board_id, title, text, hash_ip) VALUES($1, $2, $3, $4) RETURNING id", boardId, title, text, hashIp).Scan(&postId)
+	err := pr.db.QueryRowContext(p, "INSERT INTO posts.posts (board_id, title, text, hash_ip) VALUES($1, $2, $3, $4) RETURNING id", boardId, title, text, hashIp).Scan(&postId)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("CreatePost: %w", err)
 	}
-	return pr.GetPost(p, strconv.Itoa(postId))
+	return pr.GetPost(p, postId)
 }
 
-func (pr postgresRepository) DeletePost(p context.Context, id string) (interface{}, error) {
-	stmt, err := pr.db.Prepare("UPDATE posts.posts SET deleted_at = NOW() WHERE id = $1")
+func (pr postgresRepository) DeletePost(p context.Context, id int64) (bool, error) {
+	stmt, err := pr.db.PrepareContext(p, "UPDATE posts.posts SET deleted_at = NOW() WHERE id = $1")
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeletePost: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(p, id)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeletePost: %w", err)
 	}
 	return true, nil
 }
 
-func (pr postgresRepository) CreateComment(p context.Context, postID string, text string, hashIp string) (interface{}, error) {
+func (pr postgresRepository) CreateComment(p context.Context, postID int64, text string, hashIp string) (*model.Comment, error) {
 	var (
-		commentId int
+		commentId int64
 	)
 	hashIp = hashingIP(hashIp)
-	err := pr.db.QueryRow("INSERT INTO posts.comments (post_id, text, hash_ip) VALUES($1, $2, $3) RETURNING id", postID, text, hashIp).Scan(&commentId)
+	err := pr.db.QueryRowContext(p, "INSERT INTO posts.comments (post_id, text, hash_ip) VALUES($1, $2, $3) RETURNING id", postID, text, hashIp).Scan(&commentId)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("CreateComment: %w", err)
 	}
-	return pr.GetComment(p, strconv.Itoa(commentId))
+	return pr.GetComment(p, commentId)
 }
 
-func (pr postgresRepository) DeleteComment(p context.Context, id string) (interface{}, error) {
-	stmt, err := pr.db.Prepare("UPDATE posts.comments SET deleted_at = NOW() WHERE id = $1")
+func (pr postgresRepository) DeleteComment(p context.Context, id int64) (bool, error) {
+	stmt, err := pr.db.PrepareContext(p, "UPDATE posts.comments SET deleted_at = NOW() WHERE id = $1")
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeleteComment: %w", err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(p, id)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("DeleteComment: %w", err)
 	}
 	return true, nil
 }
@@ -1,19 +1,48 @@
 package repository
 
-import "context"
+import (
+	"context"
 
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+)
+
+// Repository takes entity IDs as int64, matching model.Board/Post/Comment's
+// ID field; callers at the HTTP/GraphQL boundary parse the wire string ID
+// via dto.ParseID before reaching this layer. int64 rather than int keeps
+// IDs a fixed 64 bits regardless of the host's native int width, since
+// database/sql's driver.Value already carries SQL integer columns as int64
+// before Scan narrows them.
 type Repository interface {
-	GetBoard(p context.Context, id string) (interface{}, error)
-	GetBoards(p context.Context, includeDeleted bool) (interface{}, error)
-	GetPost(p context.Context, id string) (interface{}, error)
-	GetPosts(p context.Context, boardID string, includeDeleted bool, limit int, offset int) (interface{}, error)
-	GetComment(p context.Context, id string) (interface{}, error)
-	GetComments(p context.Context, postID string, includeDeleted bool, limit int, offset int) (interface{}, error)
-	CreateBoard(p context.Context, name string, description string) (interface{}, error)
-	DeleteBoard(p context.Context, id string) (interface{}, error)
-	RestoreBoard(p context.Context, id string) (interface{}, error)
-	CreatePost(p context.Context, boardId string, title string, text string, hashIp string) (interface{}, error)
-	DeletePost(p context.Context, id string) (interface{}, error)
-	CreateComment(p context.Context, postID string, text string, hashIp string) (interface{}, error)
-	DeleteComment(p context.Context, id string) (interface{}, error)
+	GetBoard(p context.Context, id int64) (*model.Board, error)
+	GetBoards(p context.Context, includeDeleted bool) ([]model.Board, error)
+	GetPost(p context.Context, id int64) (*model.Post, error)
+	// GetPosts returns a Relay-style page: forward pagination (first/after)
+	// and backward pagination (last/before) are mutually exclusive, as in
+	// the Relay Connection spec.
+	GetPosts(p context.Context, boardID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.PostPage, error)
+	GetComment(p context.Context, id int64) (*model.Comment, error)
+	GetComments(p context.Context, postID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.CommentPage, error)
+	CreateBoard(p context.Context, name string, description string) (*model.Board, error)
+	DeleteBoard(p context.Context, id int64) (bool, error)
+	RestoreBoard(p context.Context, id int64) (bool, error)
+	CreatePost(p context.Context, boardId int64, title string, text string, hashIp string) (*model.Post, error)
+	DeletePost(p context.Context, id int64) (bool, error)
+	CreateComment(p context.Context, postID int64, text string, hashIp string) (*model.Comment, error)
+	DeleteComment(p context.Context, id int64) (bool, error)
+
+	// Batch lookups used by DataLoader-style loaders to avoid N+1 queries
+	// when resolving nested GraphQL fields. The paginated variants return
+	// each parent's first page of children in a single round trip via
+	// row_number() OVER (PARTITION BY ...), keyed by parent id.
+	GetBoardsByIDs(p context.Context, ids []int64) ([]model.Board, error)
+	GetPostsByBoardIDsPage(p context.Context, boardIDs []int64, first int, after *model.Cursor) (map[int64]model.PostPage, error)
+	GetCommentsByPostIDsPage(p context.Context, postIDs []int64, first int, after *model.Cursor) (map[int64]model.CommentPage, error)
+
+	// MigrationStage lets an operator snapshot rows from one backend and
+	// replay them into another: StoreMigrationStage persists an opaque
+	// payload under id, GetMigrationStage retrieves it, and
+	// DeleteMigrationStage clears it once the replay has landed.
+	StoreMigrationStage(p context.Context, id string, payload []byte) error
+	GetMigrationStage(p context.Context, id string) ([]byte, error)
+	DeleteMigrationStage(p context.Context, id string) error
 }
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+)
+
+// placeholders returns a "?, ?, ..." list of n placeholders, since SQLite's
+// driver has no array-bind equivalent to Postgres's ANY($1)/pq.Array.
+func placeholders(n int) string {
+	marks := make([]string, n)
+	for i := range marks {
+		marks[i] = "?"
+	}
+	return strings.Join(marks, ", ")
+}
+
+func (sr sqliteRepository) GetBoardsByIDs(ctx context.Context, ids []int64) ([]model.Board, error) {
+	query := fmt.Sprintf("SELECT id, name, description, created_at, deleted_at FROM boards WHERE id IN (%s)", placeholders(len(ids)))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := sr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var boards []model.Board
+	for rows.Next() {
+		board := model.Board{}
+		if err := rows.Scan(&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt); err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+	return boards, rows.Err()
+}
+
+// scanPostRowWithTotal is scanPostRow plus the per-board total column the
+// batched queries below add via count(*) OVER (PARTITION BY board_id), so
+// nested PostConnection.totalCount reflects the real child count rather
+// than the page length.
+func scanPostRowWithTotal(rows *sql.Rows) (model.Post, int, error) {
+	var post model.Post
+	var total int
+	err := rows.Scan(&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt, &total)
+	return post, total, err
+}
+
+func scanCommentRowWithTotal(rows *sql.Rows) (model.Comment, int, error) {
+	var comment model.Comment
+	var total int
+	err := rows.Scan(&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt, &total)
+	return comment, total, err
+}
+
+// GetPostsByBoardIDsPage is the SQLite counterpart of the Postgres loader
+// query: a row_number() window partitioned by board_id gives each board its
+// own page-1..N window in a single round trip. The total column comes from
+// a count(*) window computed before the cursor condition is applied, so it
+// reports the board's real child count rather than the page length.
+func (sr sqliteRepository) GetPostsByBoardIDsPage(ctx context.Context, boardIDs []int64, first int, after *model.Cursor) (map[int64]model.PostPage, error) {
+	query := fmt.Sprintf(`
+		SELECT id, board_id, title, text, hash_ip, created_at, deleted_at, total FROM (
+			SELECT *, row_number() OVER (PARTITION BY board_id ORDER BY created_at DESC, id DESC) AS rn
+			FROM (
+				SELECT *, count(*) OVER (PARTITION BY board_id) AS total
+				FROM posts
+				WHERE deleted_at IS NULL AND board_id IN (%s)
+			) totaled`, placeholders(len(boardIDs)))
+	args := make([]interface{}, len(boardIDs))
+	for i, id := range boardIDs {
+		args[i] = id
+	}
+	if after != nil {
+		query += " WHERE (created_at, id) < (?, ?)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+		) WHERE rn <= ?
+		ORDER BY board_id, created_at DESC, id DESC`
+	args = append(args, first+1)
+
+	rows, err := sr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byBoard := make(map[int64][]model.Post)
+	totals := make(map[int64]int)
+	for rows.Next() {
+		post, total, err := scanPostRowWithTotal(rows)
+		if err != nil {
+			return nil, err
+		}
+		byBoard[post.BoardID] = append(byBoard[post.BoardID], post)
+		totals[post.BoardID] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pages := make(map[int64]model.PostPage, len(byBoard))
+	for k, posts := range byBoard {
+		hasNext := len(posts) > first
+		if hasNext {
+			posts = posts[:first]
+		}
+		pages[k] = newPostPage(posts, hasNext, after != nil, totals[k])
+	}
+	return pages, nil
+}
+
+// GetCommentsByPostIDsPage is the comment-side counterpart of
+// GetPostsByBoardIDsPage, windowed per post_id instead of board_id.
+func (sr sqliteRepository) GetCommentsByPostIDsPage(ctx context.Context, postIDs []int64, first int, after *model.Cursor) (map[int64]model.CommentPage, error) {
+	query := fmt.Sprintf(`
+		SELECT id, post_id, text, hash_ip, created_at, deleted_at, total FROM (
+			SELECT *, row_number() OVER (PARTITION BY post_id ORDER BY created_at DESC, id DESC) AS rn
+			FROM (
+				SELECT *, count(*) OVER (PARTITION BY post_id) AS total
+				FROM comments
+				WHERE deleted_at IS NULL AND post_id IN (%s)
+			) totaled`, placeholders(len(postIDs)))
+	args := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		args[i] = id
+	}
+	if after != nil {
+		query += " WHERE (created_at, id) < (?, ?)"
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += `
+		) WHERE rn <= ?
+		ORDER BY post_id, created_at DESC, id DESC`
+	args = append(args, first+1)
+
+	rows, err := sr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byPost := make(map[int64][]model.Comment)
+	totals := make(map[int64]int)
+	for rows.Next() {
+		comment, total, err := scanCommentRowWithTotal(rows)
+		if err != nil {
+			return nil, err
+		}
+		byPost[comment.PostID] = append(byPost[comment.PostID], comment)
+		totals[comment.PostID] = total
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pages := make(map[int64]model.CommentPage, len(byPost))
+	for k, comments := range byPost {
+		hasNext := len(comments) > first
+		if hasNext {
+			comments = comments[:first]
+		}
+		pages[k] = newCommentPage(comments, hasNext, after != nil, totals[k])
+	}
+	return pages, nil
+}
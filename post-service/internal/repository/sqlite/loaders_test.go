@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+	"github.com/mattn/go-sqlite3"
+)
+
+// countingDriver wraps the real sqlite3 driver, counting every statement
+// issued through QueryContext. It exists so this test can assert the
+// DataLoader batching internal/repository/loaders.go provides actually
+// collapses N+1 lookups into a fixed number of round trips, rather than
+// only asserting on the resolved data.
+type countingDriver struct {
+	sqlite3.SQLiteDriver
+	queries *int64
+}
+
+func (d countingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return countingConn{conn, d.queries}, nil
+}
+
+type countingConn struct {
+	driver.Conn
+	queries *int64
+}
+
+func (c countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, sql.ErrConnDone
+	}
+	atomic.AddInt64(c.queries, 1)
+	return queryer.QueryContext(ctx, query, args)
+}
+
+var registerCountingDriverOnce sync.Once
+
+// openCountingDB opens a fresh in-memory SQLite database through
+// countingDriver and lays down the same schema migrations/sqlite applies,
+// so *queries ticks up once per SQL statement issued from that point on.
+// The driver is registered once, wired to the first caller's counter.
+func openCountingDB(t *testing.T, queries *int64) *sql.DB {
+	t.Helper()
+
+	registerCountingDriverOnce.Do(func() {
+		sql.Register("sqlite3_counting", countingDriver{queries: queries})
+	})
+
+	// database/sql keys pooled connections by DSN, so every test needs its
+	// own unique in-memory database name to avoid reusing another test's
+	// schema/rows.
+	db, err := sql.Open("sqlite3_counting", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, stmt := range []string{
+		`CREATE TABLE boards (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			name        TEXT NOT NULL,
+			description TEXT,
+			created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at  DATETIME
+		)`,
+		`CREATE TABLE posts (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			board_id   INTEGER NOT NULL REFERENCES boards (id),
+			title      TEXT,
+			text       TEXT NOT NULL,
+			hash_ip    TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+		`CREATE TABLE comments (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			post_id    INTEGER NOT NULL REFERENCES posts (id),
+			text       TEXT NOT NULL,
+			hash_ip    TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME
+		)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("schema setup: %v", err)
+		}
+	}
+
+	*queries = 0
+	return db
+}
+
+// TestLoadersBatchPostsAndCommentsInTwoStatements seeds a board with several
+// posts, each with several comments, then resolves Board.posts followed by
+// Post.comments for every post the way the GraphQL resolvers do: through
+// *repository.Loaders rather than direct repository calls. It asserts that
+// resolving all of it issues exactly two SQL statements - one batched
+// GetPostsByBoardIDsPage call and one batched GetCommentsByPostIDsPage call -
+// rather than one query per post/comment (N+1).
+func TestLoadersBatchPostsAndCommentsInTwoStatements(t *testing.T) {
+	var queries int64
+	db := openCountingDB(t, &queries)
+	repo := sqliteRepository{db: db}
+
+	res, err := db.Exec("INSERT INTO boards (name) VALUES ('general')")
+	if err != nil {
+		t.Fatalf("insert board: %v", err)
+	}
+	boardID, _ := res.LastInsertId()
+
+	const postCount, commentsPerPost = 3, 3
+	for i := 0; i < postCount; i++ {
+		res, err := db.Exec("INSERT INTO posts (board_id, text) VALUES (?, 'post')", boardID)
+		if err != nil {
+			t.Fatalf("insert post: %v", err)
+		}
+		postID, _ := res.LastInsertId()
+		for j := 0; j < commentsPerPost; j++ {
+			if _, err := db.Exec("INSERT INTO comments (post_id, text) VALUES (?, 'comment')", postID); err != nil {
+				t.Fatalf("insert comment: %v", err)
+			}
+		}
+	}
+
+	// Reset the counter: everything above is test setup, not part of the
+	// batching behavior under test.
+	atomic.StoreInt64(&queries, 0)
+
+	ctx := context.Background()
+	loaders := repository.NewLoaders(repo)
+
+	postsThunk := loaders.PostsByBoardID(postCount, nil).Load(ctx, strconv.FormatInt(boardID, 10))
+	page, err := postsThunk()
+	if err != nil {
+		t.Fatalf("load posts: %v", err)
+	}
+	if len(page.Posts) != postCount {
+		t.Fatalf("got %d posts, want %d", len(page.Posts), postCount)
+	}
+
+	// Queue every post's comments before awaiting any of them, so the
+	// DataLoader's wait window coalesces all of them into one batch.
+	thunks := make([]func(), 0, len(page.Posts))
+	errs := make([]error, len(page.Posts))
+	for i, post := range page.Posts {
+		thunk := loaders.CommentsByPostID(commentsPerPost, nil).Load(ctx, strconv.FormatInt(post.ID, 10))
+		i, thunk := i, thunk
+		thunks = append(thunks, func() {
+			commentPage, err := thunk()
+			errs[i] = err
+			if err == nil && len(commentPage.Comments) != commentsPerPost {
+				t.Errorf("post %d: got %d comments, want %d", post.ID, len(commentPage.Comments), commentsPerPost)
+			}
+		})
+	}
+	for _, await := range thunks {
+		await()
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("load comments: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt64(&queries); got != 2 {
+		t.Fatalf("got %d SQL statements resolving board.posts -> post.comments, want 2", got)
+	}
+}
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// Migrator is the SQLite counterpart of postgres.Migrator, used by the
+// standalone `post-service migrate` CLI subcommand.
+func Migrator(conf config.SQLite) (*migrate.Migrate, error) {
+	db, err := sql.Open("sqlite3", conf.Path)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %v", err)
+	}
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3.WithInstance: %v", err)
+	}
+	migrations := fmt.Sprintf("file://%v", conf.Migrations)
+	m, err := migrate.NewWithDatabaseInstance(migrations, "sqlite3", driver)
+	if err != nil {
+		return nil, fmt.Errorf("migrate.NewWithDatabaseInstance: %v", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import "context"
+
+// StoreMigrationStage upserts payload under id, so re-staging the same
+// snapshot (e.g. a retried export job) overwrites rather than conflicts.
+func (sr sqliteRepository) StoreMigrationStage(p context.Context, id string, payload []byte) error {
+	_, err := sr.db.ExecContext(p,
+		`INSERT INTO migration_stages (id, payload) VALUES (?, ?)
+		 ON CONFLICT (id) DO UPDATE SET payload = excluded.payload, created_at = CURRENT_TIMESTAMP`,
+		id, payload)
+	return err
+}
+
+func (sr sqliteRepository) GetMigrationStage(p context.Context, id string) ([]byte, error) {
+	var payload []byte
+	err := sr.db.QueryRowContext(p, "SELECT payload FROM migration_stages WHERE id = ?", id).Scan(&payload)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (sr sqliteRepository) DeleteMigrationStage(p context.Context, id string) error {
+	_, err := sr.db.ExecContext(p, "DELETE FROM migration_stages WHERE id = ?", id)
+	return err
+}
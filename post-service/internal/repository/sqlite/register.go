@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/gfdmit/web-forum/post-service/internal/repository"
+)
+
+func init() {
+	repository.Register("sqlite", openDSN)
+}
+
+// openDSN builds a sqliteRepository from a "sqlite://" DSN, for
+// repository.Open. The scheme is stripped to leave the file path the
+// go-sqlite3 driver expects (e.g. "sqlite://./posts.db" -> "./posts.db").
+func openDSN(dsn string) (repository.Repository, error) {
+	path := strings.TrimPrefix(dsn, "sqlite://")
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %v", err)
+	}
+	if err := db.PingContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("db.PingContext: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	return &sqliteRepository{db: db}, nil
+}
@@ -0,0 +1,218 @@
+// Package sqlite implements repository.Repository on top of database/sql
+// and a single SQLite file, so post-service can run without a Postgres
+// instance (local development, tests against a throwaway file, etc). It
+// mirrors internal/repository/postgres's structure and keyset pagination
+// scheme; the SQL dialect is the only real difference.
+package sqlite
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/gfdmit/web-forum/post-service/config"
+	"github.com/gfdmit/web-forum/post-service/internal/model"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+func New(conf config.SQLite) (*sqliteRepository, error) {
+	db, err := sql.Open("sqlite3", conf.Path)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %v", err)
+	}
+	if err := db.PingContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("db.PingContext: %v", err)
+	}
+	// SQLite only allows one writer at a time; serializing access here
+	// avoids "database is locked" errors under concurrent requests.
+	db.SetMaxOpenConns(1)
+
+	return &sqliteRepository{db: db}, nil
+}
+
+func (sr sqliteRepository) GetBoard(p context.Context, id int64) (*model.Board, error) {
+	board := &model.Board{}
+	err := sr.db.QueryRowContext(p, "SELECT id, name, description, created_at, deleted_at FROM boards WHERE id = ?", id).Scan(
+		&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("GetBoard: %w", err)
+	}
+	return board, nil
+}
+
+func (sr sqliteRepository) GetBoards(p context.Context, includeDeleted bool) ([]model.Board, error) {
+	query := "SELECT id, name, description, created_at, deleted_at FROM boards"
+	if !includeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
+	rows, err := sr.db.QueryContext(p, query)
+	if err != nil {
+		return nil, fmt.Errorf("GetBoards: %w", err)
+	}
+	defer rows.Close()
+
+	boards := []model.Board{}
+	for rows.Next() {
+		board := model.Board{}
+		if err := rows.Scan(&board.ID, &board.Name, &board.Description, &board.CreatedAt, &board.DeletedAt); err != nil {
+			return nil, fmt.Errorf("GetBoards: %w", err)
+		}
+		boards = append(boards, board)
+	}
+	return boards, rows.Err()
+}
+
+func (sr sqliteRepository) GetPost(p context.Context, id int64) (*model.Post, error) {
+	post := &model.Post{}
+	err := sr.db.QueryRowContext(p, "SELECT id, board_id, title, text, hash_ip, created_at, deleted_at FROM posts WHERE id = ?", id).Scan(
+		&post.ID, &post.BoardID, &post.Title, &post.Text, &post.HashIP, &post.CreatedAt, &post.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("GetPost: %w", err)
+	}
+	return post, nil
+}
+
+func (sr sqliteRepository) GetPosts(ctx context.Context, boardID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.PostPage, error) {
+	posts, hasNext, hasPrev, err := paginate(ctx, sr.db, "posts", "board_id", boardID, includeDeleted, first, after, last, before, scanPostRow)
+	if err != nil {
+		return model.PostPage{}, fmt.Errorf("GetPosts: %w", err)
+	}
+
+	total, err := sr.countPosts(ctx, boardID, includeDeleted)
+	if err != nil {
+		return model.PostPage{}, fmt.Errorf("GetPosts: %w", err)
+	}
+
+	return newPostPage(posts, hasNext, hasPrev, total), nil
+}
+
+func (sr sqliteRepository) countPosts(ctx context.Context, boardID int64, includeDeleted bool) (int, error) {
+	query := "SELECT COUNT(*) FROM posts WHERE board_id = ?"
+	if !includeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
+	var count int
+	err := sr.db.QueryRowContext(ctx, query, boardID).Scan(&count)
+	return count, err
+}
+
+func (sr sqliteRepository) GetComment(p context.Context, id int64) (*model.Comment, error) {
+	comment := &model.Comment{}
+	err := sr.db.QueryRowContext(p, "SELECT id, post_id, text, hash_ip, created_at, deleted_at FROM comments WHERE id = ?", id).Scan(
+		&comment.ID, &comment.PostID, &comment.Text, &comment.HashIP, &comment.CreatedAt, &comment.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("GetComment: %w", err)
+	}
+	return comment, nil
+}
+
+func (sr sqliteRepository) GetComments(ctx context.Context, postID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.CommentPage, error) {
+	comments, hasNext, hasPrev, err := paginate(ctx, sr.db, "comments", "post_id", postID, includeDeleted, first, after, last, before, scanCommentRow)
+	if err != nil {
+		return model.CommentPage{}, fmt.Errorf("GetComments: %w", err)
+	}
+
+	total, err := sr.countComments(ctx, postID, includeDeleted)
+	if err != nil {
+		return model.CommentPage{}, fmt.Errorf("GetComments: %w", err)
+	}
+
+	return newCommentPage(comments, hasNext, hasPrev, total), nil
+}
+
+func (sr sqliteRepository) countComments(ctx context.Context, postID int64, includeDeleted bool) (int, error) {
+	query := "SELECT COUNT(*) FROM comments WHERE post_id = ?"
+	if !includeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
+	var count int
+	err := sr.db.QueryRowContext(ctx, query, postID).Scan(&count)
+	return count, err
+}
+
+func (sr sqliteRepository) CreateBoard(p context.Context, name string, description string) (*model.Board, error) {
+	res, err := sr.db.ExecContext(p, "INSERT INTO boards (name, description) VALUES (?, ?)", name, description)
+	if err != nil {
+		return nil, fmt.Errorf("CreateBoard: %w", err)
+	}
+	boardID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("CreateBoard: %w", err)
+	}
+	return sr.GetBoard(p, boardID)
+}
+
+func (sr sqliteRepository) DeleteBoard(p context.Context, id int64) (bool, error) {
+	_, err := sr.db.ExecContext(p, "UPDATE boards SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("DeleteBoard: %w", err)
+	}
+	return true, nil
+}
+
+func (sr sqliteRepository) RestoreBoard(p context.Context, id int64) (bool, error) {
+	_, err := sr.db.ExecContext(p, "UPDATE boards SET deleted_at = NULL WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("RestoreBoard: %w", err)
+	}
+	return true, nil
+}
+
+func (sr sqliteRepository) CreatePost(p context.Context, boardId int64, title string, text string, hashIp string) (*model.Post, error) {
+	hashIp = hashingIP(hashIp)
+	res, err := sr.db.ExecContext(p, "INSERT INTO posts (board_id, title, text, hash_ip) VALUES (?, ?, ?, ?)", boardId, title, text, hashIp)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePost: %w", err)
+	}
+	postID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("CreatePost: %w", err)
+	}
+	return sr.GetPost(p, postID)
+}
+
+func (sr sqliteRepository) DeletePost(p context.Context, id int64) (bool, error) {
+	_, err := sr.db.ExecContext(p, "UPDATE posts SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("DeletePost: %w", err)
+	}
+	return true, nil
+}
+
+func (sr sqliteRepository) CreateComment(p context.Context, postID int64, text string, hashIp string) (*model.Comment, error) {
+	hashIp = hashingIP(hashIp)
+	res, err := sr.db.ExecContext(p, "INSERT INTO comments (post_id, text, hash_ip) VALUES (?, ?, ?)", postID, text, hashIp)
+	if err != nil {
+		return nil, fmt.Errorf("CreateComment: %w", err)
+	}
+	commentID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("CreateComment: %w", err)
+	}
+	return sr.GetComment(p, commentID)
+}
+
+func (sr sqliteRepository) DeleteComment(p context.Context, id int64) (bool, error) {
+	_, err := sr.db.ExecContext(p, "UPDATE comments SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("DeleteComment: %w", err)
+	}
+	return true, nil
+}
+
+func hashingIP(hashIp string) string {
+	hasher := sha1.New()
+	hasher.Write([]byte(hashIp))
+	hashIp = base64.URLEncoding.EncodeToString(hasher.Sum(nil))
+	return hashIp
+}
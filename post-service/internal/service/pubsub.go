@@ -0,0 +1,70 @@
+package service
+
+import "sync"
+
+type EventType string
+
+const (
+	EventPostCreated    EventType = "postCreated"
+	EventPostDeleted    EventType = "postDeleted"
+	EventCommentCreated EventType = "commentCreated"
+	EventCommentDeleted EventType = "commentDeleted"
+)
+
+// Event is published by Service whenever a mutation changes a post or a
+// comment. BoardID is set on postCreated events and PostID on postDeleted
+// and commentCreated events, so subscribers can filter those without
+// decoding Payload; commentDeleted events set neither.
+type Event struct {
+	Type    EventType
+	BoardID string
+	PostID  string
+	Payload interface{}
+}
+
+// Broker is a minimal in-process pub/sub used to fan mutation events out to
+// active GraphQL subscriptions. It is not meant to survive process restarts
+// or to be shared across instances.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a new listener and returns its event channel along
+// with an unsubscribe func that must be called once the caller is done
+// reading, typically on client disconnect.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// Publish fans e out to every active subscriber. A subscriber whose buffer
+// is full misses e rather than blocking mutations; it stays subscribed.
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
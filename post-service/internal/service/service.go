@@ -2,66 +2,98 @@ package service
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/gfdmit/web-forum/post-service/internal/model"
 	"github.com/gfdmit/web-forum/post-service/internal/repository"
 )
 
 type Service struct {
-	repo repository.Repository
+	repo   repository.Repository
+	broker *Broker
 }
 
 func New(repo repository.Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, broker: NewBroker()}
 }
 
-func (svc *Service) GetBoard(p context.Context, id string) (interface{}, error) {
+// Subscribe registers a listener for post/comment mutation events. Callers
+// must invoke the returned unsubscribe func once they stop reading, e.g. on
+// WS disconnect.
+func (svc *Service) Subscribe() (<-chan Event, func()) {
+	return svc.broker.Subscribe()
+}
+
+func (svc *Service) GetBoard(p context.Context, id int64) (*model.Board, error) {
 	return svc.repo.GetBoard(p, id)
 }
 
-func (svc *Service) GetBoards(p context.Context, includeDeleted bool) (interface{}, error) {
+func (svc *Service) GetBoards(p context.Context, includeDeleted bool) ([]model.Board, error) {
 	return svc.repo.GetBoards(p, includeDeleted)
 }
 
-func (svc *Service) GetPost(p context.Context, id string) (interface{}, error) {
+func (svc *Service) GetPost(p context.Context, id int64) (*model.Post, error) {
 	return svc.repo.GetPost(p, id)
 }
 
-func (svc *Service) GetPosts(p context.Context, boardID string, includeDeleted bool, limit int, offset int) (interface{}, error) {
-	return svc.repo.GetPosts(p, boardID, includeDeleted, limit, offset)
+func (svc *Service) GetPosts(p context.Context, boardID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.PostPage, error) {
+	return svc.repo.GetPosts(p, boardID, includeDeleted, first, after, last, before)
 }
 
-func (svc *Service) GetComment(p context.Context, id string) (interface{}, error) {
+func (svc *Service) GetComment(p context.Context, id int64) (*model.Comment, error) {
 	return svc.repo.GetComment(p, id)
 }
 
-func (svc *Service) GetComments(p context.Context, postID string, includeDeleted bool, limit int, offset int) (interface{}, error) {
-	return svc.repo.GetComments(p, postID, includeDeleted, limit, offset)
+func (svc *Service) GetComments(p context.Context, postID int64, includeDeleted bool, first *int, after *model.Cursor, last *int, before *model.Cursor) (model.CommentPage, error) {
+	return svc.repo.GetComments(p, postID, includeDeleted, first, after, last, before)
 }
 
-func (svc *Service) CreateBoard(p context.Context, name string, description string) (interface{}, error) {
+func (svc *Service) CreateBoard(p context.Context, name string, description string) (*model.Board, error) {
 	return svc.repo.CreateBoard(p, name, description)
 }
 
-func (svc *Service) DeleteBoard(p context.Context, id string) (interface{}, error) {
+func (svc *Service) DeleteBoard(p context.Context, id int64) (bool, error) {
 	return svc.repo.DeleteBoard(p, id)
 }
 
-func (svc *Service) RestoreBoard(p context.Context, id string) (interface{}, error) {
+func (svc *Service) RestoreBoard(p context.Context, id int64) (bool, error) {
 	return svc.repo.RestoreBoard(p, id)
 }
 
-func (svc *Service) CreatePost(p context.Context, boardId string, title string, text string, hashIp string) (interface{}, error) {
-	return svc.repo.CreatePost(p, boardId, title, text, hashIp)
+func (svc *Service) CreatePost(p context.Context, boardId int64, title string, text string, hashIp string) (*model.Post, error) {
+	post, err := svc.repo.CreatePost(p, boardId, title, text, hashIp)
+	if err != nil {
+		return nil, err
+	}
+	svc.broker.Publish(Event{Type: EventPostCreated, BoardID: strconv.FormatInt(boardId, 10), Payload: post})
+	return post, nil
 }
 
-func (svc *Service) DeletePost(p context.Context, id string) (interface{}, error) {
-	return svc.repo.DeletePost(p, id)
+func (svc *Service) DeletePost(p context.Context, id int64) (bool, error) {
+	ok, err := svc.repo.DeletePost(p, id)
+	if err != nil {
+		return false, err
+	}
+	idStr := strconv.FormatInt(id, 10)
+	svc.broker.Publish(Event{Type: EventPostDeleted, PostID: idStr, Payload: idStr})
+	return ok, nil
 }
 
-func (svc *Service) CreateComment(p context.Context, postID string, text string, hashIp string) (interface{}, error) {
-	return svc.repo.CreateComment(p, postID, text, hashIp)
+func (svc *Service) CreateComment(p context.Context, postID int64, text string, hashIp string) (*model.Comment, error) {
+	comment, err := svc.repo.CreateComment(p, postID, text, hashIp)
+	if err != nil {
+		return nil, err
+	}
+	svc.broker.Publish(Event{Type: EventCommentCreated, PostID: strconv.FormatInt(postID, 10), Payload: comment})
+	return comment, nil
 }
 
-func (svc *Service) DeleteComment(p context.Context, id string) (interface{}, error) {
-	return svc.repo.DeleteComment(p, id)
+func (svc *Service) DeleteComment(p context.Context, id int64) (bool, error) {
+	ok, err := svc.repo.DeleteComment(p, id)
+	if err != nil {
+		return false, err
+	}
+	idStr := strconv.FormatInt(id, 10)
+	svc.broker.Publish(Event{Type: EventCommentDeleted, Payload: idStr})
+	return ok, nil
 }